@@ -0,0 +1,16 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+// Cache stores NormalizedAddress results keyed by CEP. Implementations
+// decide their own eviction and persistence strategy; a zero-value TTL
+// passed to Set means "no expiration".
+type Cache interface {
+	Get(ctx context.Context, key string) (providers.NormalizedAddress, bool, error)
+	Set(ctx context.Context, key string, value providers.NormalizedAddress, ttl time.Duration) error
+}
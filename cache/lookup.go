@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+// DefaultRaceTimeout bounds a shared upstream race when NewLookup is
+// given a zero raceTimeout.
+const DefaultRaceTimeout = 5 * time.Second
+
+// Lookup wraps a provider registry with a Cache and singleflight
+// deduplication, so concurrent requests for the same CEP share a
+// single upstream race instead of each starting their own.
+type Lookup struct {
+	cache       Cache
+	ttl         time.Duration
+	raceTimeout time.Duration
+	providers   []providers.CepProvider
+	metrics     *Metrics
+	group       singleflight.Group
+}
+
+// NewLookup builds a Lookup backed by c with the given TTL. raceTimeout
+// bounds how long a shared upstream race is allowed to run, independent
+// of any single caller's context; a zero raceTimeout uses
+// DefaultRaceTimeout. Passing a nil metrics uses an unregistered
+// Metrics instance.
+func NewLookup(c Cache, ttl, raceTimeout time.Duration, metrics *Metrics, cepProviders ...providers.CepProvider) *Lookup {
+	if metrics == nil {
+		metrics = NewMetrics(nil)
+	}
+
+	if raceTimeout == 0 {
+		raceTimeout = DefaultRaceTimeout
+	}
+
+	return &Lookup{
+		cache:       c,
+		ttl:         ttl,
+		raceTimeout: raceTimeout,
+		providers:   cepProviders,
+		metrics:     metrics,
+	}
+}
+
+// Fetch returns the NormalizedAddress for cep, from cache when
+// possible, otherwise from the fastest provider in the race.
+//
+// The upstream race runs on a context detached from any single
+// caller, bounded only by l.raceTimeout, so a caller whose own context
+// is cancelled (client disconnect, a shorter per-request timeout, ...)
+// doesn't cut the race short for every other caller sharing it via
+// singleflight. Each caller separately waits for the shared result or
+// its own ctx.Done().
+func (l *Lookup) Fetch(ctx context.Context, cep string) (providers.NormalizedAddress, error) {
+	if address, ok, err := l.cache.Get(ctx, cep); err == nil && ok {
+		l.metrics.Hits.Inc()
+		return address, nil
+	}
+
+	l.metrics.Misses.Inc()
+
+	resultCh := l.group.DoChan(cep, func() (interface{}, error) {
+		raceCtx, cancel := context.WithTimeout(context.Background(), l.raceTimeout)
+		defer cancel()
+
+		return providers.Race(raceCtx, cep, l.providers...)
+	})
+
+	select {
+	case result := <-resultCh:
+		if result.Shared {
+			l.metrics.SingleflightShared.Inc()
+		}
+
+		if result.Err != nil {
+			return providers.NormalizedAddress{}, result.Err
+		}
+
+		address := result.Val.(providers.NormalizedAddress)
+
+		_ = l.cache.Set(ctx, cep, address, l.ttl)
+
+		return address, nil
+	case <-ctx.Done():
+		return providers.NormalizedAddress{}, ctx.Err()
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+type slowProvider struct {
+	calls int32
+}
+
+func (p *slowProvider) Name() string {
+	return "slow"
+}
+
+func (p *slowProvider) Fetch(ctx context.Context, cep string) (providers.NormalizedAddress, error) {
+	atomic.AddInt32(&p.calls, 1)
+	time.Sleep(10 * time.Millisecond)
+	return providers.NormalizedAddress{Cep: cep, Provider: "slow"}, nil
+}
+
+func TestLookupCachesResults(t *testing.T) {
+	provider := &slowProvider{}
+	lookup := NewLookup(NewMemoryCache(10), time.Minute, time.Second, nil, provider)
+
+	if _, err := lookup.Fetch(context.Background(), "06233030"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := lookup.Fetch(context.Background(), "06233030"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if atomic.LoadInt32(&provider.calls) != 1 {
+		t.Errorf("expected the provider to be called once, got %d calls", provider.calls)
+	}
+}
+
+func TestLookupDeduplicatesConcurrentRequests(t *testing.T) {
+	provider := &slowProvider{}
+	lookup := NewLookup(NewMemoryCache(10), time.Minute, time.Second, nil, provider)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := lookup.Fetch(context.Background(), "06233030"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt32(&provider.calls) != 1 {
+		t.Errorf("expected the provider to be called once, got %d calls", provider.calls)
+	}
+}
+
+// TestLookupIsolatesFollowersFromLeaderCancellation ensures the shared
+// upstream race is bounded by its own timeout, not the context of
+// whichever caller happened to start it. A follower with a live context
+// must not fail just because the leader's context was cancelled.
+func TestLookupIsolatesFollowersFromLeaderCancellation(t *testing.T) {
+	provider := &slowProvider{}
+	lookup := NewLookup(NewMemoryCache(10), time.Minute, time.Second, nil, provider)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+
+	leaderErrCh := make(chan error, 1)
+	go func() {
+		_, err := lookup.Fetch(leaderCtx, "06233030")
+		leaderErrCh <- err
+	}()
+
+	time.Sleep(2 * time.Millisecond) // let the leader register the singleflight call
+
+	followerErrCh := make(chan error, 1)
+	go func() {
+		_, err := lookup.Fetch(context.Background(), "06233030")
+		followerErrCh <- err
+	}()
+
+	cancelLeader()
+
+	if err := <-leaderErrCh; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the leader to observe its own cancellation, got %v", err)
+	}
+
+	if err := <-followerErrCh; err != nil {
+		t.Fatalf("expected the follower to succeed despite the leader's cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+type memoryEntry struct {
+	key       string
+	value     providers.NormalizedAddress
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process LRU Cache. It is the default backend:
+// no extra infrastructure is needed to get deduplication and TTL
+// expiry within a single replica.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewMemoryCache builds a MemoryCache holding at most capacity entries,
+// evicting the least recently used one once full.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (providers.NormalizedAddress, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+
+	if !ok {
+		return providers.NormalizedAddress{}, false, nil
+	}
+
+	entry := elem.Value.(*memoryEntry)
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return providers.NormalizedAddress{}, false, nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value providers.NormalizedAddress, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value = &memoryEntry{key: key, value: value, expiresAt: expiresAt}
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	elem := c.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	c.items[key] = elem
+
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryEntry).key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	c := NewMemoryCache(10)
+
+	if _, ok, err := c.Get(context.Background(), "06233030"); err != nil || ok {
+		t.Fatalf("expected a miss on an empty cache, got ok=%v err=%v", ok, err)
+	}
+
+	address := providers.NormalizedAddress{Cep: "06233030", Provider: "apicep"}
+
+	if err := c.Set(context.Background(), "06233030", address, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, ok, err := c.Get(context.Background(), "06233030")
+
+	if err != nil || !ok {
+		t.Fatalf("expected a hit, got ok=%v err=%v", ok, err)
+	}
+
+	if got != address {
+		t.Errorf("expected %+v, got %+v", address, got)
+	}
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	c := NewMemoryCache(10)
+
+	c.Set(context.Background(), "06233030", providers.NormalizedAddress{}, time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, err := c.Get(context.Background(), "06233030"); err != nil || ok {
+		t.Fatalf("expected the entry to have expired, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set(context.Background(), "a", providers.NormalizedAddress{Cep: "a"}, time.Minute)
+	c.Set(context.Background(), "b", providers.NormalizedAddress{Cep: "b"}, time.Minute)
+	c.Set(context.Background(), "c", providers.NormalizedAddress{Cep: "c"}, time.Minute)
+
+	if _, ok, _ := c.Get(context.Background(), "a"); ok {
+		t.Error("expected the least recently used entry to have been evicted")
+	}
+
+	if _, ok, _ := c.Get(context.Background(), "c"); !ok {
+		t.Error("expected the most recently set entry to still be cached")
+	}
+}
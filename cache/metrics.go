@@ -0,0 +1,37 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics tracks how often a Lookup answers from cache versus having to
+// race the upstream providers. Passing a nil Registerer to NewMetrics
+// keeps the counters usable without exposing them anywhere.
+type Metrics struct {
+	Hits               prometheus.Counter
+	Misses             prometheus.Counter
+	SingleflightShared prometheus.Counter
+}
+
+// NewMetrics builds a Metrics instance, registering its counters with
+// registerer when it is non-nil.
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		Hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cep_cache_hits_total",
+			Help: "Number of CEP lookups answered directly from the cache.",
+		}),
+		Misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cep_cache_misses_total",
+			Help: "Number of CEP lookups that required racing the upstream providers.",
+		}),
+		SingleflightShared: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cep_cache_singleflight_shared_total",
+			Help: "Number of CEP lookups whose upstream race result was shared with another concurrent lookup, including the lookup that started the race.",
+		}),
+	}
+
+	if registerer != nil {
+		registerer.MustRegister(m.Hits, m.Misses, m.SingleflightShared)
+	}
+
+	return m
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+// RedisCache is a Cache backend suitable for sharing results across
+// server replicas, unlike MemoryCache which is per-process.
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisCache builds a RedisCache using client, namespacing every key
+// with prefix.
+func NewRedisCache(client *redis.Client, prefix string) *RedisCache {
+	return &RedisCache{client: client, prefix: prefix}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) (providers.NormalizedAddress, bool, error) {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+
+	if errors.Is(err, redis.Nil) {
+		return providers.NormalizedAddress{}, false, nil
+	}
+
+	if err != nil {
+		return providers.NormalizedAddress{}, false, err
+	}
+
+	var address providers.NormalizedAddress
+
+	if err := json.Unmarshal(data, &address); err != nil {
+		return providers.NormalizedAddress{}, false, err
+	}
+
+	return address, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value providers.NormalizedAddress, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, c.prefix+key, data, ttl).Err()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/joaosczip/go-expert-multithreading/cache"
+	"github.com/joaosczip/go-expert-multithreading/providers"
+	"github.com/joaosczip/go-expert-multithreading/server"
+)
+
+func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
+	addr := flag.String("addr", ":8080", "address the server listens on")
+	timeout := flag.Duration("timeout", durationEnv("CEP_TIMEOUT", time.Second), "how long a lookup may wait for a provider (env: CEP_TIMEOUT)")
+	cacheTTL := flag.Duration("cache-ttl", 5*time.Minute, "how long a resolved cep is cached for")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	registry := []providers.CepProvider{
+		providers.WithResilience(providers.NewApiCepProvider(), providers.DefaultRetryPolicy(), providers.NewCircuitBreaker(5, 10*time.Second, 30*time.Second)),
+		providers.WithResilience(providers.NewViaCepProvider(), providers.DefaultRetryPolicy(), providers.NewCircuitBreaker(5, 10*time.Second, 30*time.Second)),
+	}
+
+	lookup := cache.NewLookup(cache.NewMemoryCache(10_000), *cacheTTL, *timeout, cache.NewMetrics(prometheus.DefaultRegisterer), registry...)
+
+	srv := server.New(*addr, *timeout, lookup)
+
+	slog.Info("listening", slog.String("addr", *addr))
+
+	if err := srv.Run(ctx); err != nil {
+		slog.Error("server stopped with error", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
+	slog.Info("server shut down gracefully")
+}
+
+// durationEnv parses env as a time.Duration, falling back to fallback
+// when the variable is unset or invalid.
+func durationEnv(env string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(env)
+	if !ok {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+
+	return duration
+}
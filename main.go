@@ -3,130 +3,43 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/http"
+	"log/slog"
+	"os"
 	"time"
-)
-
-const API_CEP_BASE_URL = "https://cdn.apicep.com/file/apicep"
-const VIACEP_BASE_URL = "http://viacep.com.br/ws"
-
-type ApiCepData struct {
-	Status   int    `json:"status"`
-	Code     string `json:"code"`
-	State    string `json:"state"`
-	City     string `json:"city"`
-	District string `json:"district"`
-	Address  string `json:"address"`
-}
-
-type ViaCepData struct {
-	Cep         string `json:"cep"`
-	Logradouro  string `json:"logradouro"`
-	Complemento string `json:"complemento"`
-	Bairro      string `json:"bairro"`
-	Localidade  string `json:"localidade"`
-	Uf          string `json:"uf"`
-	Ibge        string `json:"ibge"`
-	Gia         string `json:"gia"`
-	Ddd         string `json:"ddd"`
-	Siafi       string `json:"siafi"`
-}
-
-type CepData interface {
-	ApiCepData | ViaCepData
-}
-
-func makeRequest[T CepData](ctx context.Context, cep, baseUrl string) (*T, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", baseUrl, nil)
-
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode >= 400 && resp.StatusCode <= 599 {
-		return nil, errors.New(string(body))
-	}
 
-	var cepData T
-	err = json.Unmarshal(body, &cepData)
+	"github.com/joaosczip/go-expert-multithreading/cache"
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
 
-	if err != nil {
-		return nil, err
-	}
+func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
-	return &cepData, nil
-}
+	timeoutDuration := time.Second * 1
 
-func getApiCep(ctx context.Context, cep string, cepCh chan<- ApiCepData) {
-	data, err := makeRequest[ApiCepData](ctx, cep, fmt.Sprintf("%s/%s.json", API_CEP_BASE_URL, cep))
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	defer cancel()
 
-	if err != nil {
-		log.Printf("unable to get the cep data from 'apicep': %s", err)
-		return
+	registry := []providers.CepProvider{
+		providers.WithResilience(providers.NewApiCepProvider(), providers.DefaultRetryPolicy(), providers.NewCircuitBreaker(5, 10*time.Second, 30*time.Second)),
+		providers.WithResilience(providers.NewViaCepProvider(), providers.DefaultRetryPolicy(), providers.NewCircuitBreaker(5, 10*time.Second, 30*time.Second)),
 	}
 
-	cepCh <- *data
-}
+	lookup := cache.NewLookup(cache.NewMemoryCache(1000), 5*time.Minute, timeoutDuration, nil, registry...)
 
-func getViaCep(ctx context.Context, cep string, cepCh chan ViaCepData) {
-	data, err := makeRequest[ViaCepData](ctx, cep, fmt.Sprintf("%s/%s/json", VIACEP_BASE_URL, cep))
+	address, err := lookup.Fetch(ctx, "06233030")
 
 	if err != nil {
-		log.Printf("unable to get the cep data from 'viacep': %s", err)
+		slog.Error("cep lookup failed", slog.String("cep", "06233030"), slog.String("err", err.Error()))
 		return
 	}
 
-	cepCh <- *data
-}
-
-func handleResponseReceived[T CepData](api string, data T) {
-	dataJson, err := json.Marshal(data)
+	addressJson, err := json.Marshal(address)
 
 	if err != nil {
-		log.Fatalf("unable to serialize the cep data into json: %s", err)
+		slog.Error("unable to serialize the cep data into json", slog.String("err", err.Error()))
+		os.Exit(1)
 	}
 
-	fmt.Printf("response received from the '%s' api. Response data: %s\n", api, string(dataJson))
-}
-
-func main() {
-	timeoutDuration := time.Second * 1
-
-	ctx := context.Background()
-	ctx, cancel := context.WithTimeout(ctx, timeoutDuration)
-
-	defer cancel()
-
-	apiCepCh := make(chan ApiCepData)
-	viaCepCh := make(chan ViaCepData)
-
-	go getApiCep(ctx, "06233-030", apiCepCh)
-	go getViaCep(ctx, "06233030", viaCepCh)
-
-	select {
-	case cepData := <-apiCepCh:
-		handleResponseReceived[ApiCepData]("apicep", cepData)
-	case cepData := <-viaCepCh:
-		handleResponseReceived[ViaCepData]("viacep", cepData)
-	case <-time.After(timeoutDuration):
-		log.Println("the request didn't finish, timeout exceeded")
-	}
+	fmt.Printf("response received from the '%s' api. Response data: %s\n", address.Provider, string(addressJson))
 }
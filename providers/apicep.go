@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const apiCepBaseURL = "https://cdn.apicep.com/file/apicep"
+
+// apiCepResponse mirrors the JSON shape returned by the apicep API.
+type apiCepResponse struct {
+	Status   int    `json:"status"`
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	City     string `json:"city"`
+	District string `json:"district"`
+	Address  string `json:"address"`
+}
+
+// ApiCepProvider resolves CEPs through the apicep API.
+type ApiCepProvider struct {
+	BaseURL string
+}
+
+// NewApiCepProvider builds an ApiCepProvider pointed at the public
+// apicep API.
+func NewApiCepProvider() *ApiCepProvider {
+	return &ApiCepProvider{BaseURL: apiCepBaseURL}
+}
+
+func (p *ApiCepProvider) Name() string {
+	return "apicep"
+}
+
+func (p *ApiCepProvider) Fetch(ctx context.Context, cep string) (NormalizedAddress, error) {
+	url := fmt.Sprintf("%s/%s.json", p.BaseURL, formatApiCep(cep))
+
+	data, statusCode, err := fetchJSON[apiCepResponse](ctx, url)
+
+	annotateHTTPFetch(ctx, url, statusCode)
+
+	if err != nil {
+		return NormalizedAddress{}, err
+	}
+
+	return NormalizedAddress{
+		Cep:          data.Code,
+		Street:       data.Address,
+		Neighborhood: data.District,
+		City:         data.City,
+		State:        data.State,
+		Provider:     p.Name(),
+	}, nil
+}
+
+// formatApiCep reformats cep into apicep's expected "NNNNN-NNN" shape.
+// Race callers (main.go, cmd/server) pass the plain 8-digit form that
+// viacep accepts as-is, so apicep needs to insert the hyphen itself
+// rather than 404 on every lookup.
+func formatApiCep(cep string) string {
+	digits := onlyDigits(cep)
+
+	if len(digits) != 8 {
+		return cep
+	}
+
+	return digits[:5] + "-" + digits[5:]
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
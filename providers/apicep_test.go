@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApiCepProviderFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/06233-030.json" {
+			t.Errorf("expected the request to use the hyphenated cep, got path %q", r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"status": 200,
+			"code": "06233-030",
+			"state": "SP",
+			"city": "Osasco",
+			"district": "Munhoz Junior",
+			"address": "Rua Maria Alves de Azevedo"
+		}`))
+	}))
+	defer server.Close()
+
+	provider := &ApiCepProvider{BaseURL: server.URL}
+
+	address, err := provider.Fetch(context.Background(), "06233030")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if address.Provider != "apicep" {
+		t.Errorf("expected provider %q, got %q", "apicep", address.Provider)
+	}
+
+	if address.City != "Osasco" {
+		t.Errorf("expected city %q, got %q", "Osasco", address.City)
+	}
+}
+
+func TestApiCepProviderFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("cep not found"))
+	}))
+	defer server.Close()
+
+	provider := &ApiCepProvider{BaseURL: server.URL}
+
+	_, err := provider.Fetch(context.Background(), "00000000")
+
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestFormatApiCep(t *testing.T) {
+	cases := map[string]string{
+		"06233030":  "06233-030",
+		"06233-030": "06233-030",
+		"invalid":   "invalid",
+	}
+
+	for input, want := range cases {
+		if got := formatApiCep(input); got != want {
+			t.Errorf("formatApiCep(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package providers
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a ResilientProvider's Fetch when its
+// circuit breaker has opened and is still within its reset timeout.
+var ErrCircuitOpen = errors.New("providers: circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker opens after failureThreshold consecutive failures
+// within failureWindow and stays open for resetTimeout, after which it
+// turns half-open. While half-open it allows every call, not just one
+// probe; the next recorded success closes it and the next recorded
+// failure opens it again. Failures that are more than failureWindow apart don't
+// accumulate towards the threshold, so a rare, spread-out failure
+// doesn't eventually trip the breaker on its own.
+type CircuitBreaker struct {
+	failureThreshold int
+	failureWindow    time.Duration
+	resetTimeout     time.Duration
+
+	mu               sync.Mutex
+	state            circuitState
+	consecutiveFails int
+	firstFailureAt   time.Time
+	openedAt         time.Time
+}
+
+// NewCircuitBreaker builds a closed CircuitBreaker that opens after
+// failureThreshold consecutive failures land within failureWindow of
+// one another, and probes again after resetTimeout.
+func NewCircuitBreaker(failureThreshold int, failureWindow, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		failureWindow:    failureWindow,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+// Allow reports whether a call may proceed, transitioning an open
+// breaker to half-open once resetTimeout has elapsed.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.resetTimeout {
+			return false
+		}
+
+		cb.state = circuitHalfOpen
+
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and resets its failure count.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = circuitClosed
+	cb.consecutiveFails = 0
+	cb.firstFailureAt = time.Time{}
+}
+
+// RecordFailure counts a failure, opening the breaker once
+// failureThreshold failures are reached within failureWindow
+// (including a failed half-open probe). A failure that arrives after
+// failureWindow has elapsed since the first one in the current streak
+// starts a new streak instead of extending the old one.
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitHalfOpen {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+
+		return
+	}
+
+	now := time.Now()
+
+	if cb.consecutiveFails == 0 || now.Sub(cb.firstFailureAt) > cb.failureWindow {
+		cb.consecutiveFails = 0
+		cb.firstFailureAt = now
+	}
+
+	cb.consecutiveFails++
+
+	if cb.consecutiveFails >= cb.failureThreshold {
+		cb.state = circuitOpen
+		cb.openedAt = now
+	}
+}
@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute, time.Minute)
+
+	cb.RecordFailure()
+
+	if !cb.Allow() {
+		t.Fatal("expected breaker to still allow calls below the threshold")
+	}
+
+	cb.RecordFailure()
+
+	if cb.Allow() {
+		t.Fatal("expected breaker to be open after reaching the threshold")
+	}
+}
+
+func TestCircuitBreakerHalfOpensAfterResetTimeout(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute, 10*time.Millisecond)
+
+	cb.RecordFailure()
+
+	if cb.Allow() {
+		t.Fatal("expected breaker to be open immediately after opening")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+
+	if !cb.Allow() {
+		t.Fatal("expected breaker to allow a half-open probe after the reset timeout")
+	}
+}
+
+func TestCircuitBreakerIgnoresFailuresOutsideWindow(t *testing.T) {
+	cb := NewCircuitBreaker(2, 10*time.Millisecond, time.Minute)
+
+	cb.RecordFailure()
+
+	time.Sleep(15 * time.Millisecond) // let the failure age out of the window
+
+	cb.RecordFailure()
+
+	if !cb.Allow() {
+		t.Fatal("expected the second failure to start a new streak instead of reaching the threshold")
+	}
+}
+
+func TestCircuitBreakerClosesOnSuccess(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute, time.Millisecond)
+
+	cb.RecordFailure()
+	time.Sleep(2 * time.Millisecond)
+
+	if !cb.Allow() {
+		t.Fatal("expected breaker to allow the half-open probe")
+	}
+
+	cb.RecordSuccess()
+
+	if !cb.Allow() {
+		t.Fatal("expected breaker to be closed after a successful probe")
+	}
+}
@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// StatusError is returned by fetchJSON when the upstream responds with
+// a 4xx/5xx status, so callers (e.g. the retry policy) can tell client
+// errors apart from server errors without parsing the body.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// fetchJSON issues a GET request to url and decodes the JSON response
+// body into T, along with the HTTP status code so callers can attach it
+// to spans/logs even on success. It replaces the old
+// makeRequest[T CepData] helper now that providers are no longer
+// constrained to a closed set of types.
+func fetchJSON[T any](ctx context.Context, url string) (*T, int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode <= 599 {
+		return nil, resp.StatusCode, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var data T
+	err = json.Unmarshal(body, &data)
+
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	return &data, resp.StatusCode, nil
+}
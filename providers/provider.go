@@ -0,0 +1,25 @@
+package providers
+
+import "context"
+
+// NormalizedAddress is the common shape every CepProvider maps its
+// upstream response onto, so callers never need to know which provider
+// answered.
+type NormalizedAddress struct {
+	Cep          string `json:"cep"`
+	Street       string `json:"street"`
+	Complement   string `json:"complement,omitempty"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	Provider     string `json:"provider"`
+}
+
+// CepProvider is implemented by anything that can resolve a Brazilian
+// CEP into a NormalizedAddress. New upstreams (e.g. BrasilAPI, OpenCEP)
+// are added by implementing this interface and registering them in
+// main, without touching the race orchestration logic.
+type CepProvider interface {
+	Name() string
+	Fetch(ctx context.Context, cep string) (NormalizedAddress, error)
+}
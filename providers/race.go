@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrNoProviders is returned by Race when called without any providers.
+var ErrNoProviders = errors.New("providers: no providers given")
+
+var tracer = otel.Tracer("github.com/joaosczip/go-expert-multithreading/providers")
+
+type raceResult struct {
+	provider string
+	address  NormalizedAddress
+	err      error
+	latency  time.Duration
+	span     trace.Span
+}
+
+// Race fans out cep to every provider concurrently and returns the
+// first successful, non-error result. As soon as a winner is found (or
+// every provider has failed), the remaining in-flight requests are
+// cancelled via ctx. Every attempt is traced and logged in the
+// background, so losing/erroring/timed-out providers are still
+// observable even though Race doesn't wait for them.
+func Race(ctx context.Context, cep string, providers ...CepProvider) (NormalizedAddress, error) {
+	if len(providers) == 0 {
+		return NormalizedAddress{}, ErrNoProviders
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	resultCh := make(chan raceResult, len(providers))
+
+	for _, provider := range providers {
+		go func(provider CepProvider) {
+			start := time.Now()
+
+			fetchCtx, span := tracer.Start(ctx, "providers.fetch", trace.WithAttributes(
+				attribute.String("provider", provider.Name()),
+				attribute.String("cep", cep),
+			))
+
+			address, err := provider.Fetch(fetchCtx, cep)
+
+			resultCh <- raceResult{
+				provider: provider.Name(),
+				address:  address,
+				err:      err,
+				latency:  time.Since(start),
+				span:     span,
+			}
+		}(provider)
+	}
+
+	winnerCh := make(chan raceResult, 1)
+
+	go collectResults(cep, resultCh, len(providers), winnerCh)
+
+	winner := <-winnerCh
+
+	cancel()
+
+	if winner.err != nil {
+		return NormalizedAddress{}, winner.err
+	}
+
+	return winner.address, nil
+}
+
+// collectResults drains every provider's result, tracing and logging
+// its outcome, and forwards the first success (or, failing that, the
+// last error) to winnerCh.
+func collectResults(cep string, resultCh <-chan raceResult, n int, winnerCh chan<- raceResult) {
+	var lastErr raceResult
+	won := false
+
+	for i := 0; i < n; i++ {
+		result := <-resultCh
+
+		outcome := classifyOutcome(result.err, won)
+
+		finishSpan(result.span, outcome, result.err)
+		logResult(cep, result, outcome)
+
+		if result.err == nil {
+			if !won {
+				won = true
+				winnerCh <- result
+			}
+			continue
+		}
+
+		lastErr = result
+	}
+
+	if !won {
+		winnerCh <- lastErr
+	}
+}
+
+// classifyOutcome maps a provider's result onto win/lose/error/timeout:
+// a nil error is the win (or a lose, if another provider already won),
+// a context.DeadlineExceeded means the caller's timeout fired, and
+// context.Canceled means the race's own cancellation cut it short
+// because another provider had already answered.
+func classifyOutcome(err error, alreadyWon bool) string {
+	if err == nil {
+		if alreadyWon {
+			return "lose"
+		}
+		return "win"
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return "lose"
+	}
+
+	return "error"
+}
+
+func finishSpan(span trace.Span, outcome string, err error) {
+	span.SetAttributes(attribute.String("outcome", outcome))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, outcome)
+	}
+
+	span.End()
+}
+
+func logResult(cep string, result raceResult, outcome string) {
+	attrs := []any{
+		slog.String("cep", cep),
+		slog.String("provider", result.provider),
+		slog.Int64("latency_ms", result.latency.Milliseconds()),
+		slog.String("status", outcome),
+	}
+
+	if result.err != nil {
+		attrs = append(attrs, slog.String("err", result.err.Error()))
+		slog.Default().Warn("cep provider fetch failed", attrs...)
+		return
+	}
+
+	slog.Default().Info("cep provider fetch finished", attrs...)
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	name    string
+	delay   time.Duration
+	address NormalizedAddress
+	err     error
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context, cep string) (NormalizedAddress, error) {
+	select {
+	case <-time.After(p.delay):
+		return p.address, p.err
+	case <-ctx.Done():
+		return NormalizedAddress{}, ctx.Err()
+	}
+}
+
+func TestRaceReturnsFastestSuccess(t *testing.T) {
+	slow := &fakeProvider{name: "slow", delay: 50 * time.Millisecond, address: NormalizedAddress{Provider: "slow"}}
+	fast := &fakeProvider{name: "fast", delay: 5 * time.Millisecond, address: NormalizedAddress{Provider: "fast"}}
+
+	address, err := Race(context.Background(), "06233030", slow, fast)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if address.Provider != "fast" {
+		t.Errorf("expected winner %q, got %q", "fast", address.Provider)
+	}
+}
+
+func TestRaceSkipsErrorsAndReturnsSuccess(t *testing.T) {
+	failing := &fakeProvider{name: "failing", delay: time.Millisecond, err: errors.New("boom")}
+	winning := &fakeProvider{name: "winning", delay: 10 * time.Millisecond, address: NormalizedAddress{Provider: "winning"}}
+
+	address, err := Race(context.Background(), "06233030", failing, winning)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if address.Provider != "winning" {
+		t.Errorf("expected winner %q, got %q", "winning", address.Provider)
+	}
+}
+
+func TestRaceReturnsErrorWhenAllFail(t *testing.T) {
+	first := &fakeProvider{name: "first", delay: time.Millisecond, err: errors.New("first failure")}
+	second := &fakeProvider{name: "second", delay: time.Millisecond, err: errors.New("second failure")}
+
+	_, err := Race(context.Background(), "06233030", first, second)
+
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestRaceReturnsErrNoProviders(t *testing.T) {
+	_, err := Race(context.Background(), "06233030")
+
+	if !errors.Is(err, ErrNoProviders) {
+		t.Fatalf("expected ErrNoProviders, got %v", err)
+	}
+}
+
+func TestClassifyOutcome(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		alreadyWon bool
+		want       string
+	}{
+		{name: "first success", err: nil, alreadyWon: false, want: "win"},
+		{name: "late success", err: nil, alreadyWon: true, want: "lose"},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: "timeout"},
+		{name: "cancelled by race", err: context.Canceled, want: "lose"},
+		{name: "upstream error", err: errors.New("boom"), want: "error"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classifyOutcome(tc.err, tc.alreadyWon); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
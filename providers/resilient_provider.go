@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// ResilientProvider wraps a CepProvider with retries (exponential
+// backoff with jitter) and a circuit breaker, so a flaky upstream
+// backs off instead of silently wasting every race it enters.
+type ResilientProvider struct {
+	provider CepProvider
+	retry    RetryPolicy
+	breaker  *CircuitBreaker
+}
+
+// WithResilience decorates provider with retry and circuit breaking
+// behavior.
+func WithResilience(provider CepProvider, retry RetryPolicy, breaker *CircuitBreaker) *ResilientProvider {
+	return &ResilientProvider{
+		provider: provider,
+		retry:    retry,
+		breaker:  breaker,
+	}
+}
+
+func (r *ResilientProvider) Name() string {
+	return r.provider.Name()
+}
+
+func (r *ResilientProvider) Fetch(ctx context.Context, cep string) (NormalizedAddress, error) {
+	if !r.breaker.Allow() {
+		return NormalizedAddress{}, ErrCircuitOpen
+	}
+
+	var lastErr error
+
+attempts:
+	for attempt := 0; attempt < r.retry.MaxAttempts; attempt++ {
+		address, err := r.provider.Fetch(ctx, cep)
+
+		if err == nil {
+			r.breaker.RecordSuccess()
+			return address, nil
+		}
+
+		lastErr = err
+
+		if !isRetryable(err) || attempt == r.retry.MaxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-time.After(r.retry.backoff(attempt)):
+		case <-ctx.Done():
+			lastErr = ctx.Err()
+			break attempts
+		}
+	}
+
+	// Only count failures the retry loop itself considers a health
+	// signal (5xx/network) against the breaker. A 4xx like cep-not-found
+	// is a routine outcome, not upstream flakiness, and shouldn't trip
+	// the breaker for every other caller of this provider.
+	if isRetryable(lastErr) {
+		r.breaker.RecordFailure()
+	}
+
+	return NormalizedAddress{}, lastErr
+}
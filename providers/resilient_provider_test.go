@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	calls int
+	errs  []error
+}
+
+func (p *countingProvider) Name() string {
+	return "counting"
+}
+
+func (p *countingProvider) Fetch(ctx context.Context, cep string) (NormalizedAddress, error) {
+	err := p.errs[p.calls]
+	p.calls++
+
+	if err != nil {
+		return NormalizedAddress{}, err
+	}
+
+	return NormalizedAddress{Provider: "counting"}, nil
+}
+
+func fastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: maxAttempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+}
+
+func TestResilientProviderRetriesOnServerError(t *testing.T) {
+	inner := &countingProvider{errs: []error{&StatusError{StatusCode: 500}, nil}}
+	provider := WithResilience(inner, fastRetryPolicy(3), NewCircuitBreaker(5, time.Minute, time.Minute))
+
+	address, err := provider.Fetch(context.Background(), "06233030")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if inner.calls != 2 {
+		t.Errorf("expected 2 calls, got %d", inner.calls)
+	}
+
+	if address.Provider != "counting" {
+		t.Errorf("expected provider %q, got %q", "counting", address.Provider)
+	}
+}
+
+func TestResilientProviderDoesNotRetryOnClientError(t *testing.T) {
+	inner := &countingProvider{errs: []error{&StatusError{StatusCode: 404}, nil}}
+	provider := WithResilience(inner, fastRetryPolicy(3), NewCircuitBreaker(5, time.Minute, time.Minute))
+
+	_, err := provider.Fetch(context.Background(), "06233030")
+
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("expected 1 call, got %d", inner.calls)
+	}
+}
+
+func TestResilientProviderOpensCircuitAfterConsecutiveFailures(t *testing.T) {
+	inner := &countingProvider{errs: []error{errors.New("boom"), errors.New("boom"), errors.New("boom")}}
+	breaker := NewCircuitBreaker(1, time.Minute, time.Minute)
+	provider := WithResilience(inner, fastRetryPolicy(1), breaker)
+
+	_, err := provider.Fetch(context.Background(), "06233030")
+
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	_, err = provider.Fetch(context.Background(), "06233030")
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("expected the circuit breaker to short-circuit the second call, got %d calls", inner.calls)
+	}
+}
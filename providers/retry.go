@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how a resilient provider retries a failed
+// Fetch: exponential backoff with jitter, capped at MaxAttempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy makes up to 3 attempts, starting at 100ms and
+// backing off exponentially up to 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
+}
+
+// backoff returns the delay to wait before the given retry attempt
+// (0-indexed), with up to 50% jitter to avoid retry storms.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay * time.Duration(1<<uint(attempt))
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+
+	return delay/2 + jitter
+}
+
+// isRetryable reports whether err is worth retrying: network errors and
+// 5xx responses are, 4xx responses and context cancellation are not.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+
+	return true
+}
@@ -0,0 +1,26 @@
+package providers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// annotateHTTPFetch records the request URL and, once known, the HTTP
+// status code as an event on the span already present in ctx (started
+// by Race for this provider's attempt). A resilient provider may retry
+// the same span across several HTTP attempts, so each call adds its own
+// event rather than overwriting span attributes, keeping every attempt
+// (e.g. a 500 followed by a successful retry) individually visible.
+func annotateHTTPFetch(ctx context.Context, url string, statusCode int) {
+	span := trace.SpanFromContext(ctx)
+
+	attrs := []attribute.KeyValue{attribute.String("http.url", url)}
+
+	if statusCode != 0 {
+		attrs = append(attrs, attribute.Int("http.status_code", statusCode))
+	}
+
+	span.AddEvent("http.fetch", trace.WithAttributes(attrs...))
+}
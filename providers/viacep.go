@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+)
+
+const viaCepBaseURL = "http://viacep.com.br/ws"
+
+// viaCepResponse mirrors the JSON shape returned by the viacep API.
+type viaCepResponse struct {
+	Cep         string `json:"cep"`
+	Logradouro  string `json:"logradouro"`
+	Complemento string `json:"complemento"`
+	Bairro      string `json:"bairro"`
+	Localidade  string `json:"localidade"`
+	Uf          string `json:"uf"`
+	Ibge        string `json:"ibge"`
+	Gia         string `json:"gia"`
+	Ddd         string `json:"ddd"`
+	Siafi       string `json:"siafi"`
+}
+
+// ViaCepProvider resolves CEPs through the viacep API.
+type ViaCepProvider struct {
+	BaseURL string
+}
+
+// NewViaCepProvider builds a ViaCepProvider pointed at the public
+// viacep API.
+func NewViaCepProvider() *ViaCepProvider {
+	return &ViaCepProvider{BaseURL: viaCepBaseURL}
+}
+
+func (p *ViaCepProvider) Name() string {
+	return "viacep"
+}
+
+func (p *ViaCepProvider) Fetch(ctx context.Context, cep string) (NormalizedAddress, error) {
+	url := fmt.Sprintf("%s/%s/json", p.BaseURL, cep)
+
+	data, statusCode, err := fetchJSON[viaCepResponse](ctx, url)
+
+	annotateHTTPFetch(ctx, url, statusCode)
+
+	if err != nil {
+		return NormalizedAddress{}, err
+	}
+
+	return NormalizedAddress{
+		Cep:          data.Cep,
+		Street:       data.Logradouro,
+		Complement:   data.Complemento,
+		Neighborhood: data.Bairro,
+		City:         data.Localidade,
+		State:        data.Uf,
+		Provider:     p.Name(),
+	}, nil
+}
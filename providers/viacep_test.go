@@ -0,0 +1,54 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestViaCepProviderFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"cep": "06233-030",
+			"logradouro": "Rua Maria Alves de Azevedo",
+			"complemento": "",
+			"bairro": "Munhoz Junior",
+			"localidade": "Osasco",
+			"uf": "SP"
+		}`))
+	}))
+	defer server.Close()
+
+	provider := &ViaCepProvider{BaseURL: server.URL}
+
+	address, err := provider.Fetch(context.Background(), "06233030")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if address.Provider != "viacep" {
+		t.Errorf("expected provider %q, got %q", "viacep", address.Provider)
+	}
+
+	if address.State != "SP" {
+		t.Errorf("expected state %q, got %q", "SP", address.State)
+	}
+}
+
+func TestViaCepProviderFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("internal error"))
+	}))
+	defer server.Close()
+
+	provider := &ViaCepProvider{BaseURL: server.URL}
+
+	_, err := provider.Fetch(context.Background(), "00000000")
+
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) handleLookupCep(w http.ResponseWriter, r *http.Request) {
+	cep := strings.TrimPrefix(r.URL.Path, "/cep/")
+
+	if cep == "" {
+		http.Error(w, "cep is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	address, err := s.resolver.Fetch(ctx, cep)
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			http.Error(w, "timeout exceeded while resolving the cep", http.StatusGatewayTimeout)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Provider", address.Provider)
+	json.NewEncoder(w).Encode(address)
+}
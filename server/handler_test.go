@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+type fakeProvider struct {
+	name    string
+	delay   time.Duration
+	address providers.NormalizedAddress
+	err     error
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context, cep string) (providers.NormalizedAddress, error) {
+	select {
+	case <-time.After(p.delay):
+		return p.address, p.err
+	case <-ctx.Done():
+		return providers.NormalizedAddress{}, ctx.Err()
+	}
+}
+
+func TestHandleLookupCepReturnsWinningProvider(t *testing.T) {
+	winner := &fakeProvider{name: "winner", delay: time.Millisecond, address: providers.NormalizedAddress{Provider: "winner", Cep: "06233030"}}
+
+	s := New(":0", time.Second, winner)
+
+	req := httptest.NewRequest(http.MethodGet, "/cep/06233030", nil)
+	rec := httptest.NewRecorder()
+
+	s.handleLookupCep(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	if got := rec.Header().Get("X-Provider"); got != "winner" {
+		t.Errorf("expected X-Provider %q, got %q", "winner", got)
+	}
+}
+
+func TestHandleLookupCepMissingCep(t *testing.T) {
+	s := New(":0", time.Second, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cep/", nil)
+	rec := httptest.NewRecorder()
+
+	s.handleLookupCep(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestMetricsRouteIsReachable(t *testing.T) {
+	s := New(":0", time.Second, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	s.httpServer.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandleLookupCepTimeout(t *testing.T) {
+	slow := &fakeProvider{name: "slow", delay: 50 * time.Millisecond, address: providers.NormalizedAddress{Provider: "slow"}}
+
+	s := New(":0", 5*time.Millisecond, slow)
+
+	req := httptest.NewRequest(http.MethodGet, "/cep/06233030", nil)
+	rec := httptest.NewRecorder()
+
+	s.handleLookupCep(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status %d, got %d", http.StatusGatewayTimeout, rec.Code)
+	}
+}
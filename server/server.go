@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+// Resolver answers a CEP lookup. cache.Lookup satisfies it, so the
+// server doesn't care whether results come from cache or a live race.
+type Resolver interface {
+	Fetch(ctx context.Context, cep string) (providers.NormalizedAddress, error)
+}
+
+// Server exposes a Resolver over HTTP.
+type Server struct {
+	httpServer *http.Server
+	resolver   Resolver
+	timeout    time.Duration
+}
+
+// New builds a Server listening on addr. timeout bounds how long a
+// single request is allowed to wait for resolver to answer.
+func New(addr string, timeout time.Duration, resolver Resolver) *Server {
+	s := &Server{
+		resolver: resolver,
+		timeout:  timeout,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cep/", s.handleLookupCep)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	s.httpServer = &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+
+	return s
+}
+
+// Run starts the HTTP server and blocks until ctx is cancelled, at
+// which point it shuts the server down gracefully, letting in-flight
+// requests finish.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+
+		return s.httpServer.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaosczip/go-expert-multithreading/providers"
+)
+
+func TestRunShutsDownGracefullyOnContextCancellation(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to reserve an address: %s", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	slow := &fakeProvider{name: "slow", delay: 50 * time.Millisecond, address: providers.NormalizedAddress{Provider: "slow", Cep: "06233030"}}
+	s := New(addr, time.Second, slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runErrCh := make(chan error, 1)
+	go func() {
+		runErrCh <- s.Run(ctx)
+	}()
+
+	waitForServer(t, addr)
+
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/cep/06233030")
+		if err != nil {
+			t.Errorf("in-flight request failed: %s", err)
+			respCh <- nil
+			return
+		}
+		respCh <- resp
+	}()
+
+	time.Sleep(5 * time.Millisecond) // let the request reach the slow provider before shutting down
+	cancel()
+
+	select {
+	case resp := <-respCh:
+		if resp == nil {
+			t.Fatal("expected an in-flight request to finish, got none")
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected the in-flight request to finish successfully, got status %d", resp.StatusCode)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("in-flight request did not finish before Run returned")
+	}
+
+	select {
+	case err := <-runErrCh:
+		if err != nil {
+			t.Fatalf("unexpected error from Run: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after its context was cancelled")
+	}
+
+	if _, err := net.DialTimeout("tcp", addr, 100*time.Millisecond); err == nil {
+		t.Error("expected the server to stop accepting connections after shutdown")
+	}
+}
+
+// waitForServer polls addr until it accepts connections or t fails.
+func waitForServer(t *testing.T, addr string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", addr, 10*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("server at %s never started accepting connections", addr)
+}